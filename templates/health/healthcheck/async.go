@@ -0,0 +1,148 @@
+package healthcheck
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+// asyncCheck runs a Check on a ticker in the background and caches the
+// last result, so that serving an HTTP probe never blocks on the
+// underlying check (an expensive DB/Redis ping, for example).
+type asyncCheck struct {
+	check    Check
+	interval time.Duration
+
+	initialDelay time.Duration
+	maxStaleness time.Duration
+
+	mu                  sync.RWMutex
+	lastErr             error
+	hasRun              bool
+	lastRun             time.Time
+	lastSuccessAt       time.Time
+	consecutiveFailures int
+}
+
+// errNotYetRun is returned while an async check is still inside its
+// initial grace period and hasn't produced a result yet.
+var errNotYetRun = fmt.Errorf("unknown: check has not yet run")
+
+// AsyncOption configures an async check registered with
+// AddLivenessCheckAsync or AddReadinessCheckAsync.
+type AsyncOption func(*asyncCheck)
+
+// WithInitialDelay sets the grace period the background goroutine waits
+// before the check's first run; until that run completes, the async
+// check reports errNotYetRun. Defaults to 0 (first run starts immediately).
+func WithInitialDelay(d time.Duration) AsyncOption {
+	return func(a *asyncCheck) { a.initialDelay = d }
+}
+
+// WithMaxStaleness sets the longest the background goroutine may go
+// without producing a fresh result before the cached result is treated
+// as failing. Defaults to 0 (disabled, i.e. never goes stale).
+func WithMaxStaleness(d time.Duration) AsyncOption {
+	return func(a *asyncCheck) { a.maxStaleness = d }
+}
+
+func newAsyncCheck(check Check, interval time.Duration, notify func(error), opts ...AsyncOption) *asyncCheck {
+	a := &asyncCheck{
+		check:    check,
+		interval: interval,
+	}
+	for _, opt := range opts {
+		opt(a)
+	}
+
+	go func() {
+		if a.initialDelay > 0 {
+			time.Sleep(a.initialDelay)
+		}
+		a.run(notify)
+
+		ticker := time.NewTicker(a.interval)
+		defer ticker.Stop()
+		for range ticker.C {
+			a.run(notify)
+		}
+	}()
+
+	return a
+}
+
+func (a *asyncCheck) run(notify func(error)) {
+	err := a.check()
+
+	a.mu.Lock()
+	a.lastErr = err
+	a.hasRun = true
+	a.lastRun = time.Now()
+	if err == nil {
+		a.lastSuccessAt = a.lastRun
+		a.consecutiveFailures = 0
+	} else {
+		a.consecutiveFailures++
+	}
+	a.mu.Unlock()
+
+	if notify != nil {
+		notify(err)
+	}
+}
+
+// Check returns the cached result of the background check. It reports
+// errNotYetRun during the initial grace period, and a staleness error
+// if the background goroutine has stopped updating.
+func (a *asyncCheck) Check() error {
+	_, _, err, _ := a.result()
+	return err
+}
+
+// result reports the check's last-recorded outcome and its
+// consecutive-failure streak. The streak advances once per actual
+// background execution (in run, above), not once per caller of result —
+// so polling an async check more often never makes it look less
+// healthy than it really is.
+func (a *asyncCheck) result() (lastCheckedAt, lastSuccessAt time.Time, lastErr error, consecutiveFailures int) {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+
+	if !a.hasRun {
+		return time.Time{}, time.Time{}, errNotYetRun, 0
+	}
+	if a.maxStaleness > 0 && time.Since(a.lastRun) > a.maxStaleness {
+		staleErr := fmt.Errorf("check result is stale: last updated %s ago", time.Since(a.lastRun).Round(time.Second))
+		return a.lastRun, a.lastSuccessAt, staleErr, a.consecutiveFailures
+	}
+	return a.lastRun, a.lastSuccessAt, a.lastErr, a.consecutiveFailures
+}
+
+// AddLivenessCheckAsync registers a liveness check that runs on a
+// background ticker instead of inline with the HTTP request, avoiding
+// probe latency and a thundering herd against downstream dependencies
+// when many replicas restart at once. Use WithInitialDelay and
+// WithMaxStaleness to configure the grace period and staleness window.
+// Returns an error without starting the background goroutine if
+// interval isn't positive.
+func (h *Handler) AddLivenessCheckAsync(name string, check Check, interval time.Duration, opts ...AsyncOption) error {
+	if interval <= 0 {
+		return fmt.Errorf("healthcheck: interval for async check %q must be positive, got %s", name, interval)
+	}
+	a := newAsyncCheck(check, interval, func(err error) { h.notify(name, "liveness", err) }, opts...)
+	h.AddLivenessCheck(name, a.Check)
+	h.registerAsyncResult(name, a.result)
+	return nil
+}
+
+// AddReadinessCheckAsync is the readiness equivalent of
+// AddLivenessCheckAsync.
+func (h *Handler) AddReadinessCheckAsync(name string, check Check, interval time.Duration, opts ...AsyncOption) error {
+	if interval <= 0 {
+		return fmt.Errorf("healthcheck: interval for async check %q must be positive, got %s", name, interval)
+	}
+	a := newAsyncCheck(check, interval, func(err error) { h.notify(name, "readiness", err) }, opts...)
+	h.AddReadinessCheck(name, a.Check)
+	h.registerAsyncResult(name, a.result)
+	return nil
+}
@@ -0,0 +1,34 @@
+package healthcheck
+
+import (
+	"fmt"
+	"runtime"
+)
+
+// MemoryCheck returns a Check that fails once allocated heap memory
+// exceeds the given fraction (0-1) of system memory obtained from the
+// process, e.g. MemoryCheck(0.9) warns once alloc passes 90% of sys.
+func MemoryCheck(maxAllocRatio float64) Check {
+	return func() error {
+		var memStats runtime.MemStats
+		runtime.ReadMemStats(&memStats)
+		allocMB := memStats.Alloc / 1024 / 1024
+		sysMB := memStats.Sys / 1024 / 1024
+
+		if sysMB > 0 && float64(allocMB) > float64(sysMB)*maxAllocRatio {
+			return fmt.Errorf("alloc %s exceeds %.0f%% of sys %s", formatMB(allocMB), maxAllocRatio*100, formatMB(sysMB))
+		}
+		return nil
+	}
+}
+
+// GoroutineCheck returns a Check that fails once the number of running
+// goroutines exceeds threshold.
+func GoroutineCheck(threshold int) Check {
+	return func() error {
+		if n := runtime.NumGoroutine(); n > threshold {
+			return fmt.Errorf("%s goroutines exceeds threshold of %d", formatInt(n), threshold)
+		}
+		return nil
+	}
+}
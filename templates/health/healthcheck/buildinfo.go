@@ -0,0 +1,19 @@
+package healthcheck
+
+import "time"
+
+// Version, GitCommit, and BuildDate are populated at link time, e.g.:
+//
+//	go build -ldflags "-X github.com/Ai-Whisperers/deploy-automated-blueprint/templates/health/healthcheck.Version=$(VERSION) \
+//	  -X .../healthcheck.GitCommit=$(git rev-parse HEAD) \
+//	  -X .../healthcheck.BuildDate=$(date -u +%Y-%m-%dT%H:%M:%SZ)"
+//
+// They're reported verbatim in every JSON probe response (an empty 204
+// carries no body) so an operator can tell which build is answering a probe.
+var (
+	Version   = "dev"
+	GitCommit = "unknown"
+	BuildDate = "unknown"
+)
+
+var startedAt = time.Now()
@@ -0,0 +1,137 @@
+// Package checks provides ready-made healthcheck.Check constructors for
+// common dependencies (DNS, TCP, HTTP, SQL, Redis) and the Go runtime,
+// so callers don't have to hand-roll the context/timeout plumbing.
+package checks
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"net"
+	"net/http"
+	"runtime"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+
+	"github.com/Ai-Whisperers/deploy-automated-blueprint/templates/health/healthcheck"
+)
+
+// DNSResolveCheck returns a Check that fails if host cannot be resolved
+// within timeout.
+func DNSResolveCheck(host string, timeout time.Duration) healthcheck.Check {
+	return func() error {
+		ctx, cancel := context.WithTimeout(context.Background(), timeout)
+		defer cancel()
+
+		_, err := net.DefaultResolver.LookupHost(ctx, host)
+		if err != nil {
+			return fmt.Errorf("failed to resolve host %q: %w", host, err)
+		}
+		return nil
+	}
+}
+
+// TCPDialCheck returns a Check that fails if a TCP connection to addr
+// cannot be established within timeout.
+func TCPDialCheck(addr string, timeout time.Duration) healthcheck.Check {
+	var dialer net.Dialer
+	return func() error {
+		ctx, cancel := context.WithTimeout(context.Background(), timeout)
+		defer cancel()
+
+		conn, err := dialer.DialContext(ctx, "tcp", addr)
+		if err != nil {
+			return fmt.Errorf("failed to dial %q: %w", addr, err)
+		}
+		return conn.Close()
+	}
+}
+
+// HTTPGetCheck returns a Check that fails if a GET to url does not
+// complete within timeout or returns a non-2xx status code.
+func HTTPGetCheck(url string, timeout time.Duration) healthcheck.Check {
+	client := &http.Client{Timeout: timeout}
+	return func() error {
+		ctx, cancel := context.WithTimeout(context.Background(), timeout)
+		defer cancel()
+
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+		if err != nil {
+			return err
+		}
+
+		resp, err := client.Do(req)
+		if err != nil {
+			return fmt.Errorf("failed to GET %q: %w", url, err)
+		}
+		defer resp.Body.Close()
+
+		if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+			return fmt.Errorf("GET %q returned status %d", url, resp.StatusCode)
+		}
+		return nil
+	}
+}
+
+// DatabasePingCheck returns a Check that fails if db does not respond to
+// a ping within timeout.
+func DatabasePingCheck(db *sql.DB, timeout time.Duration) healthcheck.Check {
+	return func() error {
+		if db == nil {
+			return fmt.Errorf("database ping failed: nil db")
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), timeout)
+		defer cancel()
+
+		if err := db.PingContext(ctx); err != nil {
+			return fmt.Errorf("database ping failed: %w", err)
+		}
+		return nil
+	}
+}
+
+// RedisPingCheck returns a Check that fails if client does not respond
+// to a PING within timeout.
+func RedisPingCheck(client redis.UniversalClient, timeout time.Duration) healthcheck.Check {
+	return func() error {
+		ctx, cancel := context.WithTimeout(context.Background(), timeout)
+		defer cancel()
+
+		if err := client.Ping(ctx).Err(); err != nil {
+			return fmt.Errorf("redis ping failed: %w", err)
+		}
+		return nil
+	}
+}
+
+// GoroutineCountCheck returns a Check that fails once the number of
+// running goroutines exceeds threshold. A threshold of 0 or less selects
+// the default of 10000, which mirrors the minio health-check adjustment
+// for busy servers.
+func GoroutineCountCheck(threshold int) healthcheck.Check {
+	if threshold <= 0 {
+		threshold = 10000
+	}
+	return func() error {
+		if n := runtime.NumGoroutine(); n > threshold {
+			return fmt.Errorf("too many goroutines (%d > %d)", n, threshold)
+		}
+		return nil
+	}
+}
+
+// GCMaxPauseCheck returns a Check that fails if the most recent garbage
+// collection pause exceeded threshold.
+func GCMaxPauseCheck(threshold time.Duration) healthcheck.Check {
+	return func() error {
+		var stats runtime.MemStats
+		runtime.ReadMemStats(&stats)
+
+		pause := time.Duration(stats.PauseNs[(stats.NumGC+255)%256])
+		if pause > threshold {
+			return fmt.Errorf("last GC pause of %s exceeds threshold of %s", pause, threshold)
+		}
+		return nil
+	}
+}
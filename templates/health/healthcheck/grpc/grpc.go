@@ -0,0 +1,206 @@
+// Package grpc bridges a healthcheck.Handler registry onto the standard
+// gRPC Health Checking Protocol (grpc.health.v1.Health), so Envoy,
+// grpc_health_probe, and other gRPC-aware meshes can consume the same
+// checks as the Kubernetes HTTP probes without duplicating check logic.
+package grpc
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"google.golang.org/grpc/codes"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
+
+	"github.com/Ai-Whisperers/deploy-automated-blueprint/templates/health/healthcheck"
+)
+
+// Server implements grpc.health.v1.Health, backed by a healthcheck.Handler.
+// Each gRPC service name maps to a group of check names registered on
+// the Handler; the service is SERVING unless one of its checks has
+// reached the Handler's failure threshold.
+type Server struct {
+	healthpb.UnimplementedHealthServer
+
+	h *healthcheck.Handler
+
+	mu       sync.Mutex
+	services map[string][]string // service name -> check names
+	watchers map[string]map[chan healthpb.HealthCheckResponse_ServingStatus]struct{}
+}
+
+// NewServer returns a Server backed by h. Register service-to-check
+// mappings with RegisterService before serving traffic.
+func NewServer(h *healthcheck.Handler) *Server {
+	s := &Server{
+		h:        h,
+		services: make(map[string][]string),
+		watchers: make(map[string]map[chan healthpb.HealthCheckResponse_ServingStatus]struct{}),
+	}
+
+	h.Observe(func(name, checkType string, err error) {
+		s.mu.Lock()
+		affected := make([]string, 0)
+		for service, names := range s.services {
+			for _, n := range names {
+				if n == name {
+					affected = append(affected, service)
+					break
+				}
+			}
+		}
+		s.mu.Unlock()
+
+		// "" is the Health Checking Protocol's overall-server status; any
+		// check belonging to a registered service also affects it.
+		if len(affected) > 0 {
+			affected = append(affected, "")
+		}
+
+		// statusFor reads cached check state rather than re-running
+		// checks, so this can't loop back into the Observe callback above.
+		for _, service := range affected {
+			s.broadcast(service, s.statusFor(service))
+		}
+	})
+
+	return s
+}
+
+// RegisterService maps a gRPC service name onto a group of checks
+// already registered on the underlying Handler via AddLivenessCheck,
+// AddReadinessCheck, or their async equivalents. The empty service name
+// is reserved by the Health Checking Protocol for overall server health
+// and aggregates every check registered under any service; it can't be
+// registered directly; RegisterService ignores a call with it.
+func (s *Server) RegisterService(serviceName string, checkNames ...string) {
+	if serviceName == "" {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.services[serviceName] = checkNames
+}
+
+// namesFor resolves a gRPC service name to the check names that back it.
+// Per the Health Checking Protocol, the empty service name means
+// "overall server health", so it aggregates every check registered
+// under any service rather than doing a literal (and never-registered)
+// map lookup on "".
+func (s *Server) namesFor(serviceName string) ([]string, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if serviceName == "" {
+		seen := make(map[string]struct{})
+		names := make([]string, 0)
+		for _, group := range s.services {
+			for _, n := range group {
+				if _, ok := seen[n]; !ok {
+					seen[n] = struct{}{}
+					names = append(names, n)
+				}
+			}
+		}
+		return names, true
+	}
+
+	names, ok := s.services[serviceName]
+	return names, ok
+}
+
+// statusFor reports a service's status from the checks' last-recorded
+// results, without executing them. It's called from the Observe
+// callback above, where executing checks again would notify observers
+// again and recurse forever.
+func (s *Server) statusFor(serviceName string) healthpb.HealthCheckResponse_ServingStatus {
+	names, ok := s.namesFor(serviceName)
+	if !ok {
+		return healthpb.HealthCheckResponse_SERVICE_UNKNOWN
+	}
+
+	if err := s.h.CachedStatus(names...); err != nil {
+		return healthpb.HealthCheckResponse_NOT_SERVING
+	}
+	return healthpb.HealthCheckResponse_SERVING
+}
+
+// evalFresh actually executes a service's checks (via Handler.RunNamed)
+// and returns the resulting status. Only call this from request paths
+// (Check, Watch's initial send) — never from the Observe callback in
+// NewServer, since RunNamed notifies observers and would recurse.
+func (s *Server) evalFresh(serviceName string) healthpb.HealthCheckResponse_ServingStatus {
+	names, ok := s.namesFor(serviceName)
+	if !ok {
+		return healthpb.HealthCheckResponse_SERVICE_UNKNOWN
+	}
+
+	if err := s.h.RunNamed(names...); err != nil {
+		return healthpb.HealthCheckResponse_NOT_SERVING
+	}
+	return healthpb.HealthCheckResponse_SERVING
+}
+
+// Check implements the unary grpc.health.v1.Health RPC.
+func (s *Server) Check(ctx context.Context, req *healthpb.HealthCheckRequest) (*healthpb.HealthCheckResponse, error) {
+	status := s.evalFresh(req.Service)
+	if req.Service != "" && status == healthpb.HealthCheckResponse_SERVICE_UNKNOWN {
+		return nil, grpcNotFound(req.Service)
+	}
+	return &healthpb.HealthCheckResponse{Status: status}, nil
+}
+
+// Watch implements the streaming grpc.health.v1.Health RPC, pushing a
+// new status whenever one of the service's underlying checks changes.
+func (s *Server) Watch(req *healthpb.HealthCheckRequest, stream healthpb.Health_WatchServer) error {
+	ch := make(chan healthpb.HealthCheckResponse_ServingStatus, 1)
+	s.subscribe(req.Service, ch)
+	defer s.unsubscribe(req.Service, ch)
+
+	if err := stream.Send(&healthpb.HealthCheckResponse{Status: s.evalFresh(req.Service)}); err != nil {
+		return err
+	}
+
+	for {
+		select {
+		case <-stream.Context().Done():
+			return stream.Context().Err()
+		case st := <-ch:
+			if err := stream.Send(&healthpb.HealthCheckResponse{Status: st}); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+func (s *Server) subscribe(serviceName string, ch chan healthpb.HealthCheckResponse_ServingStatus) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.watchers[serviceName] == nil {
+		s.watchers[serviceName] = make(map[chan healthpb.HealthCheckResponse_ServingStatus]struct{})
+	}
+	s.watchers[serviceName][ch] = struct{}{}
+}
+
+func (s *Server) unsubscribe(serviceName string, ch chan healthpb.HealthCheckResponse_ServingStatus) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.watchers[serviceName], ch)
+}
+
+func (s *Server) broadcast(serviceName string, status healthpb.HealthCheckResponse_ServingStatus) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for ch := range s.watchers[serviceName] {
+		select {
+		case ch <- status:
+		default:
+			// Slow watcher; drop the update rather than block the observer.
+		}
+	}
+}
+
+func grpcNotFound(service string) error {
+	return status.Error(codes.NotFound, fmt.Sprintf("unknown service %q", service))
+}
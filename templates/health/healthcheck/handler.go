@@ -0,0 +1,395 @@
+// Package healthcheck provides a pluggable liveness/readiness handler for
+// Kubernetes-style HTTP probes, modelled after the heptiolabs/healthcheck
+// registry pattern: checks are registered by name and run on demand rather
+// than hard-coded into the handler.
+package healthcheck
+
+import (
+	"encoding/json"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Check is a single health check. A nil error means the check passed.
+type Check func() error
+
+// ResponseMode controls what a probe endpoint writes on success.
+// Failures are always reported as a JSON body with 503, regardless of
+// mode, since the caller needs to know which check failed.
+type ResponseMode int
+
+const (
+	// ResponseModeNoContent writes an empty 204 No Content on success
+	// (the default, and the cheapest to parse for a kubelet probe). A
+	// request with ?full=1 still gets a 200 OK with a JSON body.
+	ResponseModeNoContent ResponseMode = iota
+	// ResponseModeJSON writes a 200 OK with a JSON body on success too,
+	// matching tools like Jaeger that expect a body either way. Per-check
+	// results are only included with ?full=1.
+	ResponseModeJSON
+)
+
+// Handler aggregates named liveness and readiness checks and serves them
+// as JSON over HTTP.
+//
+// Liveness checks should only fail when the process itself is broken and
+// should be restarted (deadlock, corrupted state). Readiness checks may
+// additionally cover external dependencies, since a failing dependency
+// should pull the pod out of the Service rather than restart it.
+type Handler struct {
+	mu        sync.RWMutex
+	liveness  map[string]Check
+	readiness map[string]Check
+	observers []Observer
+	states    map[string]*checkState
+
+	// asyncResults holds one result reader per async-registered check,
+	// keyed by name. Async checks track their own consecutive-failure
+	// streak from their background goroutine's actual executions (see
+	// asyncCheck.result), so handle/RunNamed/CachedStatus read from here
+	// instead of deriving a streak from how often they were polled.
+	asyncResults map[string]func() (lastCheckedAt, lastSuccessAt time.Time, lastErr error, consecutiveFailures int)
+
+	failureThreshold int
+	responseMode     ResponseMode
+}
+
+// Observer is notified every time a check runs, whether triggered by an
+// HTTP probe or by an async check's background ticker. It's the
+// extension point subpackages like healthcheck/prometheus use to mirror
+// check results elsewhere without this package depending on them.
+type Observer func(name, checkType string, err error)
+
+// Observe registers an Observer that is called after every run of every
+// registered check.
+func (h *Handler) Observe(obs Observer) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.observers = append(h.observers, obs)
+}
+
+func (h *Handler) notify(name, checkType string, err error) {
+	h.mu.RLock()
+	observers := h.observers
+	h.mu.RUnlock()
+	for _, obs := range observers {
+		obs(name, checkType, err)
+	}
+}
+
+// NewHandler returns an empty Handler ready to have checks registered on
+// it. By default a check must fail once to be reported unhealthy; use
+// SetFailureThreshold to require consecutive failures before flipping,
+// similar to Kubernetes probe semantics.
+func NewHandler() *Handler {
+	return &Handler{
+		liveness:         make(map[string]Check),
+		readiness:        make(map[string]Check),
+		states:           make(map[string]*checkState),
+		asyncResults:     make(map[string]func() (time.Time, time.Time, error, int)),
+		failureThreshold: 1,
+	}
+}
+
+// registerAsyncResult wires an async check's own result reader in for
+// name, so recordResult/readResult consult it instead of a checkState.
+func (h *Handler) registerAsyncResult(name string, result func() (lastCheckedAt, lastSuccessAt time.Time, lastErr error, consecutiveFailures int)) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.asyncResults[name] = result
+}
+
+// recordResult runs after a check has actually been invoked (err is its
+// result). For a sync check, it updates and reads the name's checkState.
+// For an async check, the invocation was just asyncCheck.Check() reading
+// a cache, so the real recording already happened inside the background
+// goroutine — recordResult just reads that state back rather than
+// layering another consecutive-failure counter on top of it.
+func (h *Handler) recordResult(name string, err error) (lastCheckedAt, lastSuccessAt time.Time, lastErr error, consecutiveFailures int) {
+	h.mu.RLock()
+	result, isAsync := h.asyncResults[name]
+	h.mu.RUnlock()
+	if isAsync {
+		return result()
+	}
+
+	state := h.stateFor(name)
+	state.record(err)
+	return state.snapshot()
+}
+
+// readResult returns the name's last-recorded result without executing
+// anything, whether it's backed by a checkState or an async check.
+func (h *Handler) readResult(name string) (lastCheckedAt, lastSuccessAt time.Time, lastErr error, consecutiveFailures int) {
+	h.mu.RLock()
+	result, isAsync := h.asyncResults[name]
+	h.mu.RUnlock()
+	if isAsync {
+		return result()
+	}
+	return h.stateFor(name).snapshot()
+}
+
+// SetFailureThreshold sets how many consecutive failures a check must
+// report before the aggregated response flips to unhealthy. This avoids
+// flapping readiness on a single transient blip.
+func (h *Handler) SetFailureThreshold(n int) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.failureThreshold = n
+}
+
+// SetResponseMode controls whether a successful probe writes an empty
+// 204 or a 200 with a JSON body. See ResponseMode.
+func (h *Handler) SetResponseMode(mode ResponseMode) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.responseMode = mode
+}
+
+// AddLivenessCheck registers a check that only runs as part of the
+// liveness probe.
+func (h *Handler) AddLivenessCheck(name string, check Check) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.liveness[name] = check
+}
+
+// AddReadinessCheck registers a check that runs as part of the readiness
+// probe. Readiness checks do not affect liveness.
+func (h *Handler) AddReadinessCheck(name string, check Check) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.readiness[name] = check
+}
+
+// namedCheck pairs a check with the name and probe type it was
+// registered under, so results can be reported and observed accurately.
+type namedCheck struct {
+	name      string
+	checkType string
+	check     Check
+}
+
+// CheckResult is the reported state of a single check. Status is one of
+// "healthy", "unhealthy", or "unknown" (an async check still inside its
+// initial grace period).
+type CheckResult struct {
+	Status              string `json:"status"`
+	LastCheckedAt       string `json:"lastCheckedAt,omitempty"`
+	LastSuccessAt       string `json:"lastSuccessAt,omitempty"`
+	LastError           string `json:"lastError,omitempty"`
+	ConsecutiveFailures int    `json:"consecutiveFailures"`
+}
+
+// HealthResponse is the body written for a probe request (both /live
+// and /ready use this shape; /ready just aggregates more checks).
+type HealthResponse struct {
+	Status    string                 `json:"status"`
+	Timestamp string                 `json:"timestamp"`
+	StartedAt string                 `json:"startedAt"`
+	Uptime    string                 `json:"uptime"`
+	Version   string                 `json:"version"`
+	GitCommit string                 `json:"gitCommit"`
+	BuildDate string                 `json:"buildDate"`
+	Checks    map[string]CheckResult `json:"checks,omitempty"`
+}
+
+// LiveEndpoint is the http.HandlerFunc for the liveness probe. It only
+// evaluates liveness checks, so a failing dependency captured by a
+// readiness check cannot trigger a pod restart.
+func (h *Handler) LiveEndpoint(w http.ResponseWriter, r *http.Request) {
+	h.handle(w, r, h.snapshot(h.liveness, "liveness"))
+}
+
+// ReadyEndpoint is the http.HandlerFunc for the readiness probe. It
+// evaluates both liveness and readiness checks, since a process that is
+// not live can't be ready either.
+func (h *Handler) ReadyEndpoint(w http.ResponseWriter, r *http.Request) {
+	checks := h.snapshot(h.liveness, "liveness")
+	checks = append(checks, h.snapshot(h.readiness, "readiness")...)
+	h.handle(w, r, checks)
+}
+
+func (h *Handler) snapshot(checks map[string]Check, checkType string) []namedCheck {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	out := make([]namedCheck, 0, len(checks))
+	for name, check := range checks {
+		out = append(out, namedCheck{name: name, checkType: checkType, check: check})
+	}
+	return out
+}
+
+func (h *Handler) handle(w http.ResponseWriter, r *http.Request, checks []namedCheck) {
+	full := r.URL.Query().Get("full") == "1"
+
+	h.mu.RLock()
+	threshold := h.failureThreshold
+	mode := h.responseMode
+	h.mu.RUnlock()
+
+	results := make(map[string]CheckResult, len(checks))
+	healthy := true
+	for _, nc := range checks {
+		err := nc.check()
+		h.notify(nc.name, nc.checkType, err)
+
+		lastCheckedAt, lastSuccessAt, lastErr, consecutiveFailures := h.recordResult(nc.name, err)
+
+		status := "healthy"
+		switch {
+		case lastErr == errNotYetRun:
+			// Still inside an async check's initial grace period; report
+			// this distinctly from a real failure so operators can tell
+			// "still warming up" from "actually broken".
+			status = "unknown"
+		case consecutiveFailures >= threshold:
+			status = "unhealthy"
+			healthy = false
+		}
+
+		result := CheckResult{
+			Status:              status,
+			ConsecutiveFailures: consecutiveFailures,
+		}
+		if !lastCheckedAt.IsZero() {
+			result.LastCheckedAt = lastCheckedAt.UTC().Format(time.RFC3339)
+		}
+		if !lastSuccessAt.IsZero() {
+			result.LastSuccessAt = lastSuccessAt.UTC().Format(time.RFC3339)
+		}
+		if lastErr != nil {
+			result.LastError = lastErr.Error()
+		}
+		results[nc.name] = result
+	}
+
+	response := HealthResponse{
+		Status:    "healthy",
+		Timestamp: time.Now().UTC().Format(time.RFC3339),
+		StartedAt: startedAt.UTC().Format(time.RFC3339),
+		Uptime:    time.Since(startedAt).Round(time.Second).String(),
+		Version:   Version,
+		GitCommit: GitCommit,
+		BuildDate: BuildDate,
+	}
+	if !healthy {
+		response.Status = "unhealthy"
+	}
+	if full {
+		response.Checks = results
+	} else if !healthy {
+		failedOnly := make(map[string]CheckResult)
+		for name, result := range results {
+			if result.Status != "healthy" {
+				failedOnly[name] = result
+			}
+		}
+		response.Checks = failedOnly
+	}
+
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	if healthy && mode == ResponseModeNoContent && !full {
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+
+	if !healthy {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	} else {
+		w.WriteHeader(http.StatusOK)
+	}
+	json.NewEncoder(w).Encode(response)
+}
+
+// RunNamed runs the named checks (looked up across both liveness and
+// readiness registrations) and returns the error of the first one that
+// has reached FailureThreshold consecutive failures, or nil if none has.
+// Names that aren't registered are skipped. It notifies observers and updates
+// check state the same way the HTTP endpoints do. It's the building
+// block healthcheck/grpc uses to map a gRPC service name onto a group of
+// checks.
+func (h *Handler) RunNamed(names ...string) error {
+	h.mu.RLock()
+	checks := make([]namedCheck, 0, len(names))
+	for _, name := range names {
+		if check, ok := h.liveness[name]; ok {
+			checks = append(checks, namedCheck{name: name, checkType: "liveness", check: check})
+			continue
+		}
+		if check, ok := h.readiness[name]; ok {
+			checks = append(checks, namedCheck{name: name, checkType: "readiness", check: check})
+		}
+	}
+	threshold := h.failureThreshold
+	h.mu.RUnlock()
+
+	var firstErr error
+	for _, nc := range checks {
+		err := nc.check()
+		h.notify(nc.name, nc.checkType, err)
+
+		_, _, lastErr, consecutiveFailures := h.recordResult(nc.name, err)
+
+		if consecutiveFailures >= threshold && firstErr == nil {
+			firstErr = lastErr
+		}
+	}
+	return firstErr
+}
+
+// CachedStatus reports whether the named checks (looked up across both
+// liveness and readiness registrations) are currently passing, using
+// only their last-recorded result and FailureThreshold — it never
+// executes a check itself. This makes it safe to call from an Observer
+// callback, unlike RunNamed, which executes checks and would notify
+// observers again, recursing forever if an observer called it back.
+// Checks that haven't run yet are treated as passing.
+func (h *Handler) CachedStatus(names ...string) error {
+	h.mu.RLock()
+	threshold := h.failureThreshold
+	h.mu.RUnlock()
+
+	for _, name := range names {
+		_, _, lastErr, consecutiveFailures := h.readResult(name)
+		if lastErr == errNotYetRun {
+			continue
+		}
+		if consecutiveFailures >= threshold {
+			return lastErr
+		}
+	}
+	return nil
+}
+
+// String returns a human-readable summary of all registered checks,
+// mainly useful for logging at startup.
+func (h *Handler) String() string {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	names := make([]string, 0, len(h.liveness)+len(h.readiness))
+	for name := range h.liveness {
+		names = append(names, "liveness:"+name)
+	}
+	for name := range h.readiness {
+		names = append(names, "readiness:"+name)
+	}
+	sort.Strings(names)
+	return strings.Join(names, ", ")
+}
+
+// formatMB renders a megabyte count for display, e.g. "128MB".
+func formatMB(mb uint64) string {
+	return strconv.FormatUint(mb, 10) + "MB"
+}
+
+// formatInt renders an integer for display.
+func formatInt(n int) string {
+	return strconv.Itoa(n)
+}
@@ -0,0 +1,37 @@
+// Package prometheus exposes healthcheck.Handler results as Prometheus
+// gauges. It's split out from the healthcheck package so that callers
+// who don't want a Prometheus dependency aren't forced to pull in
+// github.com/prometheus/client_golang.
+package prometheus
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/Ai-Whisperers/deploy-automated-blueprint/templates/health/healthcheck"
+)
+
+// Install registers one gauge per check on reg and wires it up to h, so
+// the gauge is updated every time that check runs, whether triggered by
+// an HTTP probe or an async check's background ticker. The gauge value
+// is 0 for a healthy check and 1 for a failing one.
+func Install(h *healthcheck.Handler, reg prometheus.Registerer, namespace string) error {
+	vec := prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "healthcheck_status",
+		Help:      "Status of healthcheck.Handler checks (0 = healthy, 1 = failing).",
+	}, []string{"check", "type"})
+
+	if err := reg.Register(vec); err != nil {
+		return err
+	}
+
+	h.Observe(func(name, checkType string, err error) {
+		value := 0.0
+		if err != nil {
+			value = 1.0
+		}
+		vec.WithLabelValues(name, checkType).Set(value)
+	})
+
+	return nil
+}
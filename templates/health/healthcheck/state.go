@@ -0,0 +1,58 @@
+package healthcheck
+
+import (
+	"sync"
+	"time"
+)
+
+// checkState tracks the result history of a single named check across
+// requests, so the handler can apply a FailureThreshold instead of
+// flipping to unhealthy on the first transient blip.
+type checkState struct {
+	mu sync.Mutex
+
+	lastCheckedAt       time.Time
+	lastSuccessAt       time.Time
+	lastErr             error
+	consecutiveFailures int
+}
+
+func (s *checkState) record(err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.lastCheckedAt = time.Now()
+	s.lastErr = err
+	switch {
+	case err == nil:
+		s.lastSuccessAt = s.lastCheckedAt
+		s.consecutiveFailures = 0
+	case err == errNotYetRun:
+		// An async check still inside its initial grace period hasn't
+		// produced a real result yet, so it shouldn't count as a failure
+		// or push the check toward FailureThreshold.
+	default:
+		s.consecutiveFailures++
+	}
+}
+
+func (s *checkState) snapshot() (lastCheckedAt, lastSuccessAt time.Time, lastErr error, consecutiveFailures int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.lastCheckedAt, s.lastSuccessAt, s.lastErr, s.consecutiveFailures
+}
+
+// stateFor returns the checkState for name, creating it on first use.
+func (h *Handler) stateFor(name string) *checkState {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.states == nil {
+		h.states = make(map[string]*checkState)
+	}
+	s, ok := h.states[name]
+	if !ok {
+		s = &checkState{}
+		h.states[name] = s
+	}
+	return s
+}